@@ -0,0 +1,73 @@
+package passwordhasher
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// GenerateArgon2idFromString creates an Argon2id password hash from a
+// password string using DefaultArgon2idParams.
+func GenerateArgon2idFromString(password string) (string, error) {
+	return GenerateArgon2idFromStringWithParams(password, DefaultArgon2idParams)
+}
+
+// GenerateArgon2idFromStringWithParams creates an Argon2id password hash
+// from a password string using the supplied parameters. The result is
+// encoded in the standard PHC string format
+// ("$argon2id$v=19$m=...,t=...,p=...$salt$hash") so it round-trips with
+// other tools.
+func GenerateArgon2idFromStringWithParams(password string, params Argon2idParams) (string, error) {
+	salt := make([]byte, params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	hash := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+
+	encoded := fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(hash),
+	)
+
+	return encoded, nil
+}
+
+// VerifyArgon2id reports whether password matches an Argon2id encoded
+// hash produced by GenerateArgon2idFromString(WithParams).
+func VerifyArgon2id(password, encodedHash string) (bool, error) {
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return false, ErrUnknownHashFormat
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return false, err
+	}
+
+	var params Argon2idParams
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return false, err
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, err
+	}
+	want, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return false, err
+	}
+
+	got := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(want)))
+
+	return subtle.ConstantTimeCompare(got, want) == 1, nil
+}
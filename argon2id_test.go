@@ -0,0 +1,35 @@
+package passwordhasher
+
+import "testing"
+
+func TestArgon2idRoundTrip(t *testing.T) {
+	const password = "hunter2"
+
+	hash, err := GenerateArgon2idFromStringWithParams(password, DefaultArgon2idParams)
+	if err != nil {
+		t.Fatalf("GenerateArgon2idFromStringWithParams: %v", err)
+	}
+
+	ok, err := VerifyArgon2id(password, hash)
+	if err != nil {
+		t.Fatalf("VerifyArgon2id(correct password): %v", err)
+	}
+	if !ok {
+		t.Fatal("VerifyArgon2id(correct password) = false, want true")
+	}
+
+	ok, err = VerifyArgon2id("wrong password", hash)
+	if err != nil {
+		t.Fatalf("VerifyArgon2id(wrong password): %v", err)
+	}
+	if ok {
+		t.Fatal("VerifyArgon2id(wrong password) = true, want false")
+	}
+}
+
+func TestVerifyArgon2idMalformed(t *testing.T) {
+	_, err := VerifyArgon2id("hunter2", "not-an-argon2id-hash")
+	if err != ErrUnknownHashFormat {
+		t.Fatalf("VerifyArgon2id(malformed) error = %v, want ErrUnknownHashFormat", err)
+	}
+}
@@ -0,0 +1,228 @@
+package passwordhasher
+
+import "strings"
+
+// Hasher is implemented by every password hashing scheme this package
+// knows about. Downstream code can add new schemes (yescrypt,
+// PBKDF2-HMAC-SHA512, LDAP "{SSHA}", ...) by implementing Hasher and
+// calling Register, without touching HashPassword or VerifyPassword.
+type Hasher interface {
+	// Name is the hashType string HashPassword dispatches on, e.g.
+	// "bcrypt" or "argon2id".
+	Name() string
+	// Hash produces an encoded hash for password, applying any opts.
+	Hash(password string, opts ...Option) (string, error)
+	// Verify reports whether password matches encodedHash.
+	Verify(password, encodedHash string) (bool, error)
+	// Recognizes reports whether encodedHash looks like this Hasher's
+	// output, so VerifyPassword can auto-detect the scheme.
+	Recognizes(encodedHash string) bool
+}
+
+var (
+	registry      = map[string]Hasher{}
+	registryOrder []string
+)
+
+// Register adds h to the set of Hashers known to HashPassword and
+// VerifyPassword, keyed by h.Name(). Registering an already-registered
+// name replaces the previous Hasher.
+func Register(h Hasher) {
+	if _, exists := registry[h.Name()]; !exists {
+		registryOrder = append(registryOrder, h.Name())
+	}
+	registry[h.Name()] = h
+}
+
+func init() {
+	Register(bcryptHasher{})
+	Register(sha512Hasher{})
+	Register(sha256Hasher{})
+	Register(apr1Hasher{})
+	Register(md5Hasher{})
+	Register(argon2idHasher{})
+	Register(scryptHasher{})
+	Register(sshaHasher{})
+	Register(ssha256Hasher{})
+	Register(ssha512Hasher{})
+}
+
+type bcryptHasher struct{}
+
+func (bcryptHasher) Name() string { return "bcrypt" }
+
+func (bcryptHasher) Hash(password string, opts ...Option) (string, error) {
+	o := applyOptions(opts)
+	if o.BcryptCost > 0 {
+		return GenerateBcryptFromStringWithCost(password, o.BcryptCost)
+	}
+	return GenerateBcryptFromString(password)
+}
+
+func (bcryptHasher) Verify(password, encodedHash string) (bool, error) {
+	return VerifyBcrypt(password, encodedHash)
+}
+
+func (bcryptHasher) Recognizes(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, "$2a$") ||
+		strings.HasPrefix(encodedHash, "$2b$") ||
+		strings.HasPrefix(encodedHash, "$2y$")
+}
+
+type sha512Hasher struct{}
+
+func (sha512Hasher) Name() string { return "sha512" }
+
+func (sha512Hasher) Hash(password string, opts ...Option) (string, error) {
+	o := applyOptions(opts)
+	return GenerateSHA512FromStringWithParams(password, o.Salt, o.Rounds)
+}
+
+func (sha512Hasher) Verify(password, encodedHash string) (bool, error) {
+	return VerifySHA512(password, encodedHash)
+}
+
+func (sha512Hasher) Recognizes(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, "$6$")
+}
+
+type sha256Hasher struct{}
+
+func (sha256Hasher) Name() string { return "sha256" }
+
+func (sha256Hasher) Hash(password string, opts ...Option) (string, error) {
+	o := applyOptions(opts)
+	return GenerateSHA256FromStringWithParams(password, o.Salt, o.Rounds)
+}
+
+func (sha256Hasher) Verify(password, encodedHash string) (bool, error) {
+	return VerifySHA256(password, encodedHash)
+}
+
+func (sha256Hasher) Recognizes(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, "$5$")
+}
+
+type apr1Hasher struct{}
+
+func (apr1Hasher) Name() string { return "apr1" }
+
+func (apr1Hasher) Hash(password string, opts ...Option) (string, error) {
+	o := applyOptions(opts)
+	return GenerateAPR1FromStringWithParams(password, o.Salt)
+}
+
+func (apr1Hasher) Verify(password, encodedHash string) (bool, error) {
+	return VerifyAPR1(password, encodedHash)
+}
+
+func (apr1Hasher) Recognizes(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, "$apr1$")
+}
+
+type md5Hasher struct{}
+
+func (md5Hasher) Name() string { return "md5" }
+
+func (md5Hasher) Hash(password string, opts ...Option) (string, error) {
+	o := applyOptions(opts)
+	return GenerateMD5FromStringWithParams(password, o.Salt)
+}
+
+func (md5Hasher) Verify(password, encodedHash string) (bool, error) {
+	return VerifyMD5(password, encodedHash)
+}
+
+func (md5Hasher) Recognizes(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, "$1$")
+}
+
+type argon2idHasher struct{}
+
+func (argon2idHasher) Name() string { return "argon2id" }
+
+func (argon2idHasher) Hash(password string, opts ...Option) (string, error) {
+	o := applyOptions(opts)
+	params := DefaultArgon2idParams
+	if o.Argon2id != nil {
+		params = *o.Argon2id
+	}
+	return GenerateArgon2idFromStringWithParams(password, params)
+}
+
+func (argon2idHasher) Verify(password, encodedHash string) (bool, error) {
+	return VerifyArgon2id(password, encodedHash)
+}
+
+func (argon2idHasher) Recognizes(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, "$argon2id$")
+}
+
+type scryptHasher struct{}
+
+func (scryptHasher) Name() string { return "scrypt" }
+
+func (scryptHasher) Hash(password string, opts ...Option) (string, error) {
+	o := applyOptions(opts)
+	params := DefaultScryptParams
+	if o.Scrypt != nil {
+		params = *o.Scrypt
+	}
+	return GenerateScryptFromStringWithParams(password, params)
+}
+
+func (scryptHasher) Verify(password, encodedHash string) (bool, error) {
+	return VerifyScrypt(password, encodedHash)
+}
+
+func (scryptHasher) Recognizes(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, "$scrypt$")
+}
+
+type sshaHasher struct{}
+
+func (sshaHasher) Name() string { return "ssha" }
+
+func (sshaHasher) Hash(password string, opts ...Option) (string, error) {
+	return GenerateSSHAFromString(password)
+}
+
+func (sshaHasher) Verify(password, encodedHash string) (bool, error) {
+	return VerifySSHA(password, encodedHash)
+}
+
+func (sshaHasher) Recognizes(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, "{SSHA}")
+}
+
+type ssha256Hasher struct{}
+
+func (ssha256Hasher) Name() string { return "ssha256" }
+
+func (ssha256Hasher) Hash(password string, opts ...Option) (string, error) {
+	return GenerateSSHA256FromString(password)
+}
+
+func (ssha256Hasher) Verify(password, encodedHash string) (bool, error) {
+	return VerifySSHA256(password, encodedHash)
+}
+
+func (ssha256Hasher) Recognizes(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, "{SSHA256}")
+}
+
+type ssha512Hasher struct{}
+
+func (ssha512Hasher) Name() string { return "ssha512" }
+
+func (ssha512Hasher) Hash(password string, opts ...Option) (string, error) {
+	return GenerateSSHA512FromString(password)
+}
+
+func (ssha512Hasher) Verify(password, encodedHash string) (bool, error) {
+	return VerifySSHA512(password, encodedHash)
+}
+
+func (ssha512Hasher) Recognizes(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, "{SSHA512}")
+}
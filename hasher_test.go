@@ -0,0 +1,42 @@
+package passwordhasher
+
+import "testing"
+
+// TestRegistryHasherSaltRoundsOptions checks that the salt/rounds Options
+// (WithSalt, WithRounds) reach the crypt-style Hashers registered in
+// init() without tripping the "invalid magic prefix" bug chunk0-4 fixed
+// in cryptSalt: the registry forwards these options straight into the
+// same Generate*WithParams functions, so it shares that fix rather than
+// needing one of its own.
+func TestRegistryHasherSaltRoundsOptions(t *testing.T) {
+	const password = "hunter2"
+	salt := []byte("abcdefgh")
+
+	tests := []struct {
+		name string
+		h    Hasher
+		opts []Option
+	}{
+		{name: "sha512", h: sha512Hasher{}, opts: []Option{WithSalt(salt), WithRounds(5000)}},
+		{name: "sha256", h: sha256Hasher{}, opts: []Option{WithSalt(salt)}},
+		{name: "apr1", h: apr1Hasher{}, opts: []Option{WithSalt(salt)}},
+		{name: "md5", h: md5Hasher{}, opts: []Option{WithSalt(salt)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := tt.h.Hash(password, tt.opts...)
+			if err != nil {
+				t.Fatalf("Hash: %v", err)
+			}
+
+			ok, err := tt.h.Verify(password, hash)
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if !ok {
+				t.Fatalf("Verify(%q) = false, want true", hash)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package htpasswd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetVerifyRoundTrip(t *testing.T) {
+	const password = "hunter2"
+
+	types := []HashType{APR1, Bcrypt, SHA, SHA256Crypt, SHA512Crypt}
+
+	for _, hashType := range types {
+		t.Run("", func(t *testing.T) {
+			f := New()
+			if err := f.Set("alice", password, hashType); err != nil {
+				t.Fatalf("Set: %v", err)
+			}
+
+			ok, err := f.Verify("alice", password)
+			if err != nil {
+				t.Fatalf("Verify(correct password): %v", err)
+			}
+			if !ok {
+				t.Fatal("Verify(correct password) = false, want true")
+			}
+
+			ok, err = f.Verify("alice", "wrong password")
+			if err != nil {
+				t.Fatalf("Verify(wrong password): %v", err)
+			}
+			if ok {
+				t.Fatal("Verify(wrong password) = true, want false")
+			}
+		})
+	}
+}
+
+func TestVerifyUnknownUser(t *testing.T) {
+	f := New()
+	ok, err := f.Verify("ghost", "anything")
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if ok {
+		t.Fatal("Verify(unknown user) = true, want false")
+	}
+}
+
+func TestLoadSaveRoundTrip(t *testing.T) {
+	f := New()
+	if err := f.Set("alice", "hunter2", APR1); err != nil {
+		t.Fatalf("Set(alice): %v", err)
+	}
+	if err := f.Set("bob", "swordfish", SHA512Crypt); err != nil {
+		t.Fatalf("Set(bob): %v", err)
+	}
+
+	var buf strings.Builder
+	if err := f.Save(&buf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := Load(strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	for _, tt := range []struct {
+		user, password string
+	}{
+		{"alice", "hunter2"},
+		{"bob", "swordfish"},
+	} {
+		ok, err := loaded.Verify(tt.user, tt.password)
+		if err != nil {
+			t.Fatalf("Verify(%s): %v", tt.user, err)
+		}
+		if !ok {
+			t.Fatalf("Verify(%s, %s) = false, want true after Load/Save round trip", tt.user, tt.password)
+		}
+	}
+
+	loaded.Delete("alice")
+	ok, err := loaded.Verify("alice", "hunter2")
+	if err != nil {
+		t.Fatalf("Verify(alice) after Delete: %v", err)
+	}
+	if ok {
+		t.Fatal("Verify(alice) = true after Delete, want false")
+	}
+}
+
+func TestLoadMalformedLine(t *testing.T) {
+	_, err := Load(strings.NewReader("alice:$apr1$salt$hash\nthis-line-has-no-colon\n"))
+	if err == nil {
+		t.Fatal("Load with malformed line = nil error, want error")
+	}
+}
+
+func TestLoadSkipsBlankAndCommentLines(t *testing.T) {
+	f, err := Load(strings.NewReader("\n# comment\nalice:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\n\n"))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	ok, err := f.Verify("alice", "password")
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !ok {
+		t.Fatal("Verify(alice, password) = false, want true")
+	}
+}
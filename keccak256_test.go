@@ -0,0 +1,18 @@
+package passwordhasher
+
+import "testing"
+
+// TestGenerateKeccak256FromStringKnownAnswer checks the empty-string
+// Keccak-256 digest against the published test vector, guarding against
+// an accidental swap with FIPS SHA3-256 (sha3.New256), which pads its
+// input differently and would produce
+// "a7ffc6f8bf1ed76651c14756a061d662f580ff4de43b49fa82d80a4b80f8434a"
+// instead.
+func TestGenerateKeccak256FromStringKnownAnswer(t *testing.T) {
+	const want = "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"
+
+	got := GenerateKeccak256FromString("")
+	if got != want {
+		t.Fatalf("GenerateKeccak256FromString(\"\") = %q, want %q", got, want)
+	}
+}
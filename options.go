@@ -0,0 +1,56 @@
+package passwordhasher
+
+// Options collects the optional parameters a Hasher implementation may
+// consult when hashing a password. Not every Hasher looks at every
+// field; consult the implementation's documentation for which apply.
+type Options struct {
+	// Salt, when non-nil, is used instead of a randomly generated one.
+	Salt []byte
+	// Rounds, when greater than zero, sets an explicit crypt(3) rounds
+	// count for the sha256_crypt/sha512_crypt backed hashers.
+	Rounds int
+	// BcryptCost, when greater than zero, overrides bcrypt.DefaultCost.
+	BcryptCost int
+	// Argon2id, when non-nil, overrides DefaultArgon2idParams.
+	Argon2id *Argon2idParams
+	// Scrypt, when non-nil, overrides DefaultScryptParams.
+	Scrypt *ScryptParams
+}
+
+// Option configures an Options value. Hashers receive Options through the
+// functional-option pattern so new knobs can be added without breaking
+// existing callers of Hasher.Hash.
+type Option func(*Options)
+
+// WithSalt sets an explicit salt.
+func WithSalt(salt []byte) Option {
+	return func(o *Options) { o.Salt = salt }
+}
+
+// WithRounds sets an explicit crypt(3) rounds count.
+func WithRounds(rounds int) Option {
+	return func(o *Options) { o.Rounds = rounds }
+}
+
+// WithBcryptCost sets an explicit bcrypt cost factor.
+func WithBcryptCost(cost int) Option {
+	return func(o *Options) { o.BcryptCost = cost }
+}
+
+// WithArgon2idParams sets explicit Argon2id parameters.
+func WithArgon2idParams(params Argon2idParams) Option {
+	return func(o *Options) { o.Argon2id = &params }
+}
+
+// WithScryptParams sets explicit scrypt parameters.
+func WithScryptParams(params ScryptParams) Option {
+	return func(o *Options) { o.Scrypt = &params }
+}
+
+func applyOptions(opts []Option) Options {
+	var o Options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
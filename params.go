@@ -0,0 +1,43 @@
+package passwordhasher
+
+// Argon2idParams holds the tunable cost parameters for Argon2id password
+// hashing.
+type Argon2idParams struct {
+	Memory      uint32 // memory cost, in KiB
+	Iterations  uint32 // time cost (number of passes over the memory)
+	Parallelism uint8  // degree of parallelism
+	SaltLength  uint32 // length of the random salt, in bytes
+	KeyLength   uint32 // length of the derived key, in bytes
+}
+
+// DefaultArgon2idParams are the RFC 9106 "moderate" profile parameters:
+// 64 MiB of memory, 3 iterations and a parallelism of 4. Callers with
+// different memory or latency budgets should tune their own Argon2idParams
+// and pass it to GenerateArgon2idFromStringWithParams.
+var DefaultArgon2idParams = Argon2idParams{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 4,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// ScryptParams holds the tunable cost parameters for scrypt password
+// hashing.
+type ScryptParams struct {
+	N          int // CPU/memory cost, must be a power of two greater than 1
+	R          int // block size
+	P          int // parallelization factor
+	SaltLength int // length of the random salt, in bytes
+	KeyLength  int // length of the derived key, in bytes
+}
+
+// DefaultScryptParams are the parameters recommended by the scrypt paper
+// for interactive logins: N=32768, r=8, p=1.
+var DefaultScryptParams = ScryptParams{
+	N:          32768,
+	R:          8,
+	P:          1,
+	SaltLength: 16,
+	KeyLength:  32,
+}
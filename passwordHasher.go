@@ -1,7 +1,9 @@
 package passwordhasher
 
 import (
+	"crypto/rand"
 	"encoding/hex"
+	"fmt"
 
 	"github.com/kless/osutil/user/crypt/apr1_crypt"
 	"github.com/kless/osutil/user/crypt/md5_crypt"
@@ -14,27 +16,16 @@ import (
 	"golang.org/x/text/transform"
 )
 
-// HashPassword is used to generate a password hash of the correct type
+// HashPassword generates a password hash using the Hasher registered
+// under hashType (see Register). It returns an error, rather than a
+// placeholder hash value, if hashType is not a registered Hasher.
 func HashPassword(password, hashType string) (string, error) {
-	var hash string
-	var err error
-
-	switch hashType {
-	case "sha512":
-		hash, err = GenerateSHA512FromString(password)
-	case "sha256":
-		hash, err = GenerateSHA256FromString(password)
-	case "bcrypt":
-		hash, err = GenerateBcryptFromString(password)
-	case "apr1":
-		hash, err = GenerateAPR1FromString(password)
-	case "md5":
-		hash, err = GenerateMD5FromString(password)
-	default:
-		hash = "Password cannot be a blank value. Please try again."
+	h, ok := registry[hashType]
+	if !ok {
+		return "", fmt.Errorf("passwordhasher: unknown hash type %q", hashType)
 	}
 
-	return hash, err
+	return h.Hash(password)
 }
 
 // GenerateSHA3ShakeSum256FromString creates a SHA3 SHAKE-256 hash from a
@@ -59,12 +50,30 @@ func GenerateSHA3ShakeSum128FromString(password string) string {
 	return hex.EncodeToString(passwordHash)
 }
 
+// GenerateKeccak256FromString creates a legacy Keccak-256 hash from a
+// password string using sha3.NewLegacyKeccak256, the construction used by
+// Ethereum/EVM-style address and content hashing. This differs from FIPS
+// SHA3-256, which pads its input differently.
+func GenerateKeccak256FromString(password string) string {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write([]byte(password))
+
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
 // GenerateSHA512FromString creates a SHA-512 password hash from a password
 // string which is compatible with Linux operating systems.
 func GenerateSHA512FromString(password string) (string, error) {
+	return GenerateSHA512FromStringWithParams(password, nil, 0)
+}
+
+// GenerateSHA512FromStringWithParams creates a SHA-512 password hash from
+// a password string using an explicit salt and rounds count, so the hash
+// can be reproduced for verification or its cost tuned. A nil salt or a
+// rounds of 0 lets the underlying crypter pick its own default.
+func GenerateSHA512FromStringWithParams(password string, salt []byte, rounds int) (string, error) {
 	crypter := sha512_crypt.New()
-	passwordByteStream := []byte(password)
-	passwordHash, err := crypter.Generate(passwordByteStream, []byte{})
+	passwordHash, err := crypter.Generate([]byte(password), cryptSalt("$6$", salt, rounds))
 	if err != nil {
 		return "", err
 	}
@@ -75,9 +84,16 @@ func GenerateSHA512FromString(password string) (string, error) {
 // GenerateSHA256FromString creates a SHA-256 password hash from a password
 // string which is compatible with Linux operating systems.
 func GenerateSHA256FromString(password string) (string, error) {
+	return GenerateSHA256FromStringWithParams(password, nil, 0)
+}
+
+// GenerateSHA256FromStringWithParams creates a SHA-256 password hash from
+// a password string using an explicit salt and rounds count, so the hash
+// can be reproduced for verification or its cost tuned. A nil salt or a
+// rounds of 0 lets the underlying crypter pick its own default.
+func GenerateSHA256FromStringWithParams(password string, salt []byte, rounds int) (string, error) {
 	crypter := sha256_crypt.New()
-	passwordByteStream := []byte(password)
-	passwordHash, err := crypter.Generate(passwordByteStream, []byte{})
+	passwordHash, err := crypter.Generate([]byte(password), cryptSalt("$5$", salt, rounds))
 	if err != nil {
 		return "", err
 	}
@@ -85,6 +101,35 @@ func GenerateSHA256FromString(password string) (string, error) {
 	return passwordHash, nil
 }
 
+// cryptSalt assembles the crypt(3) style salt parameter expected by the
+// sha256_crypt/sha512_crypt/apr1_crypt/md5_crypt Generate methods, which
+// validate that the salt starts with the scheme's magic prefix ("$6$",
+// "$5$", "$apr1$" or "$1$"). An explicit rounds count is embedded as
+// "<prefix>rounds=N$salt" when rounds is greater than zero. With no salt
+// and no rounds, an empty salt is returned so the crypter generates and
+// prefixes its own random salt, matching the original zero-arg behavior.
+// With no salt but an explicit rounds count, a random 16 character salt
+// is generated here, since an empty salt parameter would drop the rounds.
+func cryptSalt(magicPrefix string, salt []byte, rounds int) []byte {
+	if salt == nil && rounds <= 0 {
+		return []byte{}
+	}
+	if salt == nil {
+		const alphabet = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+		salt = make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			panic("passwordhasher: reading random salt: " + err.Error())
+		}
+		for i, b := range salt {
+			salt[i] = alphabet[int(b)%len(alphabet)]
+		}
+	}
+	if rounds > 0 {
+		return []byte(fmt.Sprintf("%srounds=%d$%s", magicPrefix, rounds, salt))
+	}
+	return []byte(fmt.Sprintf("%s%s", magicPrefix, salt))
+}
+
 // GenerateMD4WindowsNTLMFromString creates a MD4 based password hash from a
 // password string which is compatible with Linux / BSD operating systems.
 func GenerateMD4WindowsNTLMFromString(password string) string {
@@ -98,8 +143,14 @@ func GenerateMD4WindowsNTLMFromString(password string) string {
 // GenerateBcryptFromString creates a Bcrypt password hash from a password
 // string which is compatible with Linux / BSD operating systems.
 func GenerateBcryptFromString(password string) (string, error) {
-	passwordByteStream := []byte(password)
-	passwordHash, err := bcrypt.GenerateFromPassword(passwordByteStream, bcrypt.DefaultCost)
+	return GenerateBcryptFromStringWithCost(password, bcrypt.DefaultCost)
+}
+
+// GenerateBcryptFromStringWithCost creates a Bcrypt password hash from a
+// password string using an explicit cost factor instead of
+// bcrypt.DefaultCost.
+func GenerateBcryptFromStringWithCost(password string, cost int) (string, error) {
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), cost)
 	if err != nil {
 		return "", err
 	}
@@ -109,9 +160,15 @@ func GenerateBcryptFromString(password string) (string, error) {
 // GenerateAPR1FromString creates a APR1 password hash from a password
 // string which is compatible with Linux operating systems.
 func GenerateAPR1FromString(password string) (string, error) {
+	return GenerateAPR1FromStringWithParams(password, nil)
+}
+
+// GenerateAPR1FromStringWithParams creates an APR1 password hash from a
+// password string using an explicit salt, so the hash can be reproduced
+// for verification. A nil salt lets the underlying crypter pick its own.
+func GenerateAPR1FromStringWithParams(password string, salt []byte) (string, error) {
 	crypter := apr1_crypt.New()
-	passwordByteStream := []byte(password)
-	passwordHash, err := crypter.Generate(passwordByteStream, []byte{})
+	passwordHash, err := crypter.Generate([]byte(password), cryptSalt("$apr1$", salt, 0))
 	if err != nil {
 		return "", err
 	}
@@ -122,9 +179,15 @@ func GenerateAPR1FromString(password string) (string, error) {
 // GenerateMD5FromString creates an MD5 password hash from a password
 // string which is compatible with Linux operating systems.
 func GenerateMD5FromString(password string) (string, error) {
+	return GenerateMD5FromStringWithParams(password, nil)
+}
+
+// GenerateMD5FromStringWithParams creates an MD5 password hash from a
+// password string using an explicit salt, so the hash can be reproduced
+// for verification. A nil salt lets the underlying crypter pick its own.
+func GenerateMD5FromStringWithParams(password string, salt []byte) (string, error) {
 	crypter := md5_crypt.New()
-	passwordByteStream := []byte(password)
-	passwordHash, err := crypter.Generate(passwordByteStream, []byte{})
+	passwordHash, err := crypter.Generate([]byte(password), cryptSalt("$1$", salt, 0))
 	if err != nil {
 		return "", err
 	}
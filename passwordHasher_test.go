@@ -0,0 +1,66 @@
+package passwordhasher
+
+import "testing"
+
+// TestGenerateWithParamsRoundTrip exercises every crypt(3) style
+// Generate*WithParams variant with an explicit salt (and, for sha512, an
+// explicit rounds count) and checks the result verifies.
+// This is the exact path chunk0-4 added: a caller-supplied salt/rounds
+// previously tripped "invalid magic prefix" errors in the underlying
+// crypter because the scheme's magic prefix was never prepended.
+func TestGenerateWithParamsRoundTrip(t *testing.T) {
+	const password = "hunter2"
+	salt := []byte("abcdefgh")
+
+	tests := []struct {
+		name     string
+		generate func() (string, error)
+		verify   func(hash string) (bool, error)
+	}{
+		{
+			name: "sha512 with salt and rounds",
+			generate: func() (string, error) {
+				return GenerateSHA512FromStringWithParams(password, salt, 5000)
+			},
+			verify: func(hash string) (bool, error) { return VerifySHA512(password, hash) },
+		},
+		{
+			name: "sha256 with salt",
+			generate: func() (string, error) {
+				return GenerateSHA256FromStringWithParams(password, salt, 0)
+			},
+			verify: func(hash string) (bool, error) { return VerifySHA256(password, hash) },
+		},
+		{
+			name: "apr1 with salt",
+			generate: func() (string, error) {
+				return GenerateAPR1FromStringWithParams(password, salt)
+			},
+			verify: func(hash string) (bool, error) { return VerifyAPR1(password, hash) },
+		},
+		{
+			name: "md5 with salt",
+			generate: func() (string, error) {
+				return GenerateMD5FromStringWithParams(password, salt)
+			},
+			verify: func(hash string) (bool, error) { return VerifyMD5(password, hash) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := tt.generate()
+			if err != nil {
+				t.Fatalf("generate: %v", err)
+			}
+
+			ok, err := tt.verify(hash)
+			if err != nil {
+				t.Fatalf("verify: %v", err)
+			}
+			if !ok {
+				t.Fatalf("verify(%q) = false, want true", hash)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package passwordhasher
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"math/bits"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// GenerateScryptFromString creates a scrypt password hash from a password
+// string using DefaultScryptParams.
+func GenerateScryptFromString(password string) (string, error) {
+	return GenerateScryptFromStringWithParams(password, DefaultScryptParams)
+}
+
+// GenerateScryptFromStringWithParams creates a scrypt password hash from a
+// password string using the supplied parameters. The result is encoded as
+// "$scrypt$ln=...,r=...,p=...$salt$hash", where ln is log2(N).
+func GenerateScryptFromStringWithParams(password string, params ScryptParams) (string, error) {
+	salt := make([]byte, params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	hash, err := scrypt.Key([]byte(password), salt, params.N, params.R, params.P, params.KeyLength)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := fmt.Sprintf(
+		"$scrypt$ln=%d,r=%d,p=%d$%s$%s",
+		bits.Len(uint(params.N))-1, params.R, params.P,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(hash),
+	)
+
+	return encoded, nil
+}
+
+// VerifyScrypt reports whether password matches a scrypt encoded hash
+// produced by GenerateScryptFromString(WithParams).
+func VerifyScrypt(password, encodedHash string) (bool, error) {
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 5 || parts[1] != "scrypt" {
+		return false, ErrUnknownHashFormat
+	}
+
+	var ln, r, p int
+	if _, err := fmt.Sscanf(parts[2], "ln=%d,r=%d,p=%d", &ln, &r, &p); err != nil {
+		return false, err
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false, err
+	}
+	want, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, err
+	}
+
+	got, err := scrypt.Key([]byte(password), salt, 1<<uint(ln), r, p, len(want))
+	if err != nil {
+		return false, err
+	}
+
+	return subtle.ConstantTimeCompare(got, want) == 1, nil
+}
@@ -0,0 +1,35 @@
+package passwordhasher
+
+import "testing"
+
+func TestScryptRoundTrip(t *testing.T) {
+	const password = "hunter2"
+
+	hash, err := GenerateScryptFromStringWithParams(password, DefaultScryptParams)
+	if err != nil {
+		t.Fatalf("GenerateScryptFromStringWithParams: %v", err)
+	}
+
+	ok, err := VerifyScrypt(password, hash)
+	if err != nil {
+		t.Fatalf("VerifyScrypt(correct password): %v", err)
+	}
+	if !ok {
+		t.Fatal("VerifyScrypt(correct password) = false, want true")
+	}
+
+	ok, err = VerifyScrypt("wrong password", hash)
+	if err != nil {
+		t.Fatalf("VerifyScrypt(wrong password): %v", err)
+	}
+	if ok {
+		t.Fatal("VerifyScrypt(wrong password) = true, want false")
+	}
+}
+
+func TestVerifyScryptMalformed(t *testing.T) {
+	_, err := VerifyScrypt("hunter2", "not-a-scrypt-hash")
+	if err != ErrUnknownHashFormat {
+		t.Fatalf("VerifyScrypt(malformed) error = %v, want ErrUnknownHashFormat", err)
+	}
+}
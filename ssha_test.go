@@ -0,0 +1,66 @@
+package passwordhasher
+
+import "testing"
+
+func TestSSHARoundTrip(t *testing.T) {
+	const password = "hunter2"
+
+	variants := []struct {
+		name     string
+		generate func() (string, error)
+		verify   func(password, hash string) (bool, error)
+	}{
+		{"ssha", func() (string, error) { return GenerateSSHAFromString(password) }, VerifySSHA},
+		{"ssha256", func() (string, error) { return GenerateSSHA256FromString(password) }, VerifySSHA256},
+		{"ssha512", func() (string, error) { return GenerateSSHA512FromString(password) }, VerifySSHA512},
+	}
+
+	for _, tt := range variants {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := tt.generate()
+			if err != nil {
+				t.Fatalf("generate: %v", err)
+			}
+
+			ok, err := tt.verify(password, hash)
+			if err != nil {
+				t.Fatalf("verify(correct password): %v", err)
+			}
+			if !ok {
+				t.Fatal("verify(correct password) = false, want true")
+			}
+
+			ok, err = tt.verify("wrong password", hash)
+			if err != nil {
+				t.Fatalf("verify(wrong password): %v", err)
+			}
+			if ok {
+				t.Fatal("verify(wrong password) = true, want false")
+			}
+		})
+	}
+}
+
+func TestVerifySaltedBadBase64(t *testing.T) {
+	if _, err := VerifySSHA("hunter2", "{SSHA}not-valid-base64!!"); err == nil {
+		t.Fatal("VerifySSHA with invalid base64 = nil error, want error")
+	}
+}
+
+func TestVerifySaltedTooShort(t *testing.T) {
+	// "{SSHA}" followed by a base64 blob shorter than a SHA-1 digest.
+	if _, err := VerifySSHA("hunter2", "{SSHA}YWJj"); err == nil {
+		t.Fatal("VerifySSHA with too-short digest = nil error, want error")
+	}
+}
+
+func TestVerifySaltedWrongTag(t *testing.T) {
+	hash, err := GenerateSSHA256FromString("hunter2")
+	if err != nil {
+		t.Fatalf("GenerateSSHA256FromString: %v", err)
+	}
+
+	if _, err := VerifySSHA("hunter2", hash); err == nil {
+		t.Fatal("VerifySSHA on a {SSHA256} hash = nil error, want error")
+	}
+}
@@ -0,0 +1,114 @@
+package passwordhasher
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+
+	"github.com/kless/osutil/user/crypt/apr1_crypt"
+	"github.com/kless/osutil/user/crypt/md5_crypt"
+	"github.com/kless/osutil/user/crypt/sha256_crypt"
+	"github.com/kless/osutil/user/crypt/sha512_crypt"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrUnknownHashFormat is returned by VerifyPassword when encodedHash does
+// not match any format this package knows how to verify.
+var ErrUnknownHashFormat = errors.New("passwordhasher: unrecognized hash format")
+
+// VerifyPassword checks password against encodedHash, auto-detecting the
+// hash type by asking each registered Hasher (see Register) whether it
+// recognizes encodedHash's format, then dispatching to its Verify
+// method. As a fallback, the fixed-length hex digest produced by
+// GenerateMD4WindowsNTLMFromString is recognized by its length, since
+// NTLM has no registered Hasher of its own.
+func VerifyPassword(password, encodedHash string) (bool, error) {
+	for _, name := range registryOrder {
+		h := registry[name]
+		if h.Recognizes(encodedHash) {
+			return h.Verify(password, encodedHash)
+		}
+	}
+
+	if len(encodedHash) == 32 && isHex(encodedHash) {
+		// The only fixed-length hex digest of this length this package
+		// produces is GenerateMD4WindowsNTLMFromString's. The SHA3 SHAKE
+		// helpers are intentionally excluded here: Shake128 and Shake256
+		// both produce 64-byte digests in this package, so their 128
+		// character hex encodings are indistinguishable by length alone.
+		// Verify those with crypto/subtle against
+		// GenerateSHA3ShakeSum128/256FromString directly.
+		return VerifyMD4WindowsNTLM(password, encodedHash), nil
+	}
+
+	return false, ErrUnknownHashFormat
+}
+
+func isHex(s string) bool {
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+func constantTimeEqual(a, b string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// VerifyBcrypt reports whether password matches a bcrypt encoded hash.
+func VerifyBcrypt(password, encodedHash string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encodedHash), []byte(password))
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, bcrypt.ErrMismatchedHashAndPassword):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// VerifySHA512 reports whether password matches a $6$ crypt-sha512
+// encoded hash.
+func VerifySHA512(password, encodedHash string) (bool, error) {
+	computed, err := sha512_crypt.New().Generate([]byte(password), []byte(encodedHash))
+	if err != nil {
+		return false, err
+	}
+	return constantTimeEqual(computed, encodedHash), nil
+}
+
+// VerifySHA256 reports whether password matches a $5$ crypt-sha256
+// encoded hash.
+func VerifySHA256(password, encodedHash string) (bool, error) {
+	computed, err := sha256_crypt.New().Generate([]byte(password), []byte(encodedHash))
+	if err != nil {
+		return false, err
+	}
+	return constantTimeEqual(computed, encodedHash), nil
+}
+
+// VerifyAPR1 reports whether password matches an $apr1$ encoded hash.
+func VerifyAPR1(password, encodedHash string) (bool, error) {
+	computed, err := apr1_crypt.New().Generate([]byte(password), []byte(encodedHash))
+	if err != nil {
+		return false, err
+	}
+	return constantTimeEqual(computed, encodedHash), nil
+}
+
+// VerifyMD5 reports whether password matches a $1$ encoded hash.
+func VerifyMD5(password, encodedHash string) (bool, error) {
+	computed, err := md5_crypt.New().Generate([]byte(password), []byte(encodedHash))
+	if err != nil {
+		return false, err
+	}
+	return constantTimeEqual(computed, encodedHash), nil
+}
+
+// VerifyMD4WindowsNTLM reports whether password matches an NTLM hex
+// digest produced by GenerateMD4WindowsNTLMFromString.
+func VerifyMD4WindowsNTLM(password, hexDigest string) bool {
+	return constantTimeEqual(GenerateMD4WindowsNTLMFromString(password), hexDigest)
+}
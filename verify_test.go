@@ -0,0 +1,77 @@
+package passwordhasher
+
+import "testing"
+
+// TestVerifyPasswordAutoDetect drives VerifyPassword across every
+// registered hash family and confirms both the correct-password and
+// wrong-password outcomes for each. The NTLM length-sniffing fallback and
+// the ErrUnknownHashFormat path are covered by the tests below.
+func TestVerifyPasswordAutoDetect(t *testing.T) {
+	const password = "hunter2"
+
+	generators := map[string]func() (string, error){
+		"argon2id": func() (string, error) { return GenerateArgon2idFromString(password) },
+		"scrypt":   func() (string, error) { return GenerateScryptFromString(password) },
+		"ssha":     func() (string, error) { return GenerateSSHAFromString(password) },
+		"ssha256":  func() (string, error) { return GenerateSSHA256FromString(password) },
+		"ssha512":  func() (string, error) { return GenerateSSHA512FromString(password) },
+		"bcrypt":   func() (string, error) { return GenerateBcryptFromString(password) },
+		"sha512":   func() (string, error) { return GenerateSHA512FromString(password) },
+		"sha256":   func() (string, error) { return GenerateSHA256FromString(password) },
+		"apr1":     func() (string, error) { return GenerateAPR1FromString(password) },
+		"md5":      func() (string, error) { return GenerateMD5FromString(password) },
+	}
+
+	for name, generate := range generators {
+		t.Run(name, func(t *testing.T) {
+			hash, err := generate()
+			if err != nil {
+				t.Fatalf("generate: %v", err)
+			}
+
+			ok, err := VerifyPassword(password, hash)
+			if err != nil {
+				t.Fatalf("VerifyPassword(correct password): %v", err)
+			}
+			if !ok {
+				t.Fatalf("VerifyPassword(%q, %q) = false, want true", password, hash)
+			}
+
+			ok, err = VerifyPassword("wrong password", hash)
+			if err != nil {
+				t.Fatalf("VerifyPassword(wrong password): %v", err)
+			}
+			if ok {
+				t.Fatalf("VerifyPassword(wrong password, %q) = true, want false", hash)
+			}
+		})
+	}
+}
+
+func TestVerifyPasswordNTLMFallback(t *testing.T) {
+	const password = "hunter2"
+	hash := GenerateMD4WindowsNTLMFromString(password)
+
+	ok, err := VerifyPassword(password, hash)
+	if err != nil {
+		t.Fatalf("VerifyPassword(correct password): %v", err)
+	}
+	if !ok {
+		t.Fatalf("VerifyPassword(%q, %q) = false, want true", password, hash)
+	}
+
+	ok, err = VerifyPassword("wrong password", hash)
+	if err != nil {
+		t.Fatalf("VerifyPassword(wrong password): %v", err)
+	}
+	if ok {
+		t.Fatal("VerifyPassword(wrong password) = true, want false")
+	}
+}
+
+func TestVerifyPasswordUnknownFormat(t *testing.T) {
+	_, err := VerifyPassword("hunter2", "not-a-recognized-hash")
+	if err != ErrUnknownHashFormat {
+		t.Fatalf("VerifyPassword(unrecognized) error = %v, want ErrUnknownHashFormat", err)
+	}
+}